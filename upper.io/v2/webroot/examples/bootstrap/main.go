@@ -0,0 +1,55 @@
+package main
+
+import (
+	"log"
+
+	"upper.io/db.v2"            // Imports the main db package.
+	"upper.io/db.v2/postgresql" // Imports the postgresql adapter.
+
+	"upper.io/db.v2/webroot/examples/bootstrap/migrations"
+)
+
+// Book represents a book.
+type Book struct {
+	ID        int    `db:"id"`
+	Title     string `db:"title"`
+	AuthorID  int    `db:"author_id"`
+	SubjectID int    `db:"subject_id"`
+}
+
+// settings points at a local Postgres instance instead of
+// demo.upper.io, so the migrations below can create and own the
+// schema. Adjust to match your local setup.
+var settings = postgresql.ConnectionURL{
+	Database: `booktown`,
+	Address:  db.ParseAddress(`localhost`),
+	User:     `postgres`,
+	Password: ``,
+}
+
+func main() {
+	sess, err := db.Open("postgresql", settings)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer sess.Close()
+
+	if err := migrations.Run(sess); err != nil {
+		log.Fatal(err)
+	}
+
+	col, err := sess.Collection("books")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var books []Book
+	if err := col.Find().Sort("id").All(&books); err != nil {
+		log.Fatal(err)
+	}
+
+	for _, book := range books {
+		log.Printf("%#v\n", book)
+	}
+}
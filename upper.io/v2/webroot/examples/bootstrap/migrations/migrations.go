@@ -0,0 +1,154 @@
+// Package migrations creates and seeds the books/authors/subjects schema
+// used by the other examples in this repository, so they can be run
+// against a local Postgres instance instead of the hosted
+// demo.upper.io database.
+package migrations
+
+import (
+	"log"
+
+	"upper.io/db.v2" // Imports the main db package.
+)
+
+// Migration is a single step applied to the database. A step need not be
+// safe to apply twice by itself: Name must be unique and is recorded in
+// the schema_migrations table so that Run can skip migrations that
+// already ran.
+type Migration struct {
+	Name string
+	Up   func(sess db.Database) error
+}
+
+// list holds every migration in the order it must be applied.
+var list = []Migration{
+	{Name: "0001_create_tables", Up: createTables},
+	{Name: "0002_seed_data", Up: seedData},
+	{Name: "0003_add_book_details_column", Up: addBookDetailsColumn},
+}
+
+// Run applies every migration in list that hasn't been recorded in the
+// schema_migrations table yet. It is safe to call Run multiple times
+// against the same database.
+func Run(sess db.Database) error {
+	if err := createMigrationsTable(sess); err != nil {
+		return err
+	}
+
+	migrations, err := sess.Collection("schema_migrations")
+	if err != nil {
+		return err
+	}
+
+	for _, m := range list {
+		found, err := migrations.Find(db.Cond{"name": m.Name}).Count()
+		if err != nil {
+			return err
+		}
+		if found > 0 {
+			continue
+		}
+
+		if err := m.Up(sess); err != nil {
+			return err
+		}
+
+		if _, err := migrations.Insert(struct {
+			Name string `db:"name"`
+		}{Name: m.Name}); err != nil {
+			return err
+		}
+
+		log.Printf("applied migration %q\n", m.Name)
+	}
+
+	return nil
+}
+
+func createMigrationsTable(sess db.Database) error {
+	_, err := sess.Exec(db.Raw(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			name TEXT PRIMARY KEY,
+			applied_at TIMESTAMP NOT NULL DEFAULT now()
+		)
+	`))
+	return err
+}
+
+func createTables(sess db.Database) error {
+	statements := []string{
+		`CREATE TABLE authors (
+			id SERIAL PRIMARY KEY,
+			name TEXT NOT NULL
+		)`,
+		`CREATE TABLE subjects (
+			id SERIAL PRIMARY KEY,
+			name TEXT NOT NULL
+		)`,
+		`CREATE TABLE books (
+			id SERIAL PRIMARY KEY,
+			title TEXT NOT NULL,
+			author_id INTEGER NOT NULL REFERENCES authors(id),
+			subject_id INTEGER NOT NULL REFERENCES subjects(id)
+		)`,
+	}
+
+	for _, stmt := range statements {
+		if _, err := sess.Exec(db.Raw(stmt)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func seedData(sess db.Database) error {
+	authors, err := sess.Collection("authors")
+	if err != nil {
+		return err
+	}
+
+	subjects, err := sess.Collection("subjects")
+	if err != nil {
+		return err
+	}
+
+	books, err := sess.Collection("books")
+	if err != nil {
+		return err
+	}
+
+	author := struct {
+		ID   int    `db:"id,omitempty"`
+		Name string `db:"name"`
+	}{Name: "William Gibson"}
+	if _, err := authors.Insert(&author); err != nil {
+		return err
+	}
+
+	subject := struct {
+		ID   int    `db:"id,omitempty"`
+		Name string `db:"name"`
+	}{Name: "Cyberpunk"}
+	if _, err := subjects.Insert(&subject); err != nil {
+		return err
+	}
+
+	_, err = books.Insert(struct {
+		Title     string `db:"title"`
+		AuthorID  int    `db:"author_id"`
+		SubjectID int    `db:"subject_id"`
+	}{
+		Title:     "Neuromancer",
+		AuthorID:  author.ID,
+		SubjectID: subject.ID,
+	})
+
+	return err
+}
+
+// addBookDetailsColumn adds the jsonb column used by the jsonb example to
+// store arbitrary book metadata (rating, genres, table of contents, ...).
+func addBookDetailsColumn(sess db.Database) error {
+	_, err := sess.Exec(db.Raw(`ALTER TABLE books ADD COLUMN IF NOT EXISTS details jsonb`))
+	return err
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"log"
+
+	"upper.io/db.v2"                // Imports the main db package.
+	"upper.io/db.v2/lib/sqlbuilder" // Imports the SQL builder interfaces.
+	"upper.io/db.v2/postgresql"     // Imports the postgresql adapter.
+)
+
+// Book represents a book.
+type Book struct {
+	ID        int    `db:"id,omitempty"`
+	Title     string `db:"title"`
+	AuthorID  int    `db:"author_id"`
+	SubjectID int    `db:"subject_id"`
+}
+
+// Author represents a book author.
+type Author struct {
+	ID   int    `db:"id,omitempty"`
+	Name string `db:"name"`
+}
+
+// Subject represents a book's subject or category.
+type Subject struct {
+	ID   int    `db:"id,omitempty"`
+	Name string `db:"name"`
+}
+
+// BookDetails is the composite struct that a books/authors/subjects join
+// is mapped into.
+type BookDetails struct {
+	ID      int    `db:"id"`
+	Title   string `db:"title"`
+	Author  string `db:"author"`
+	Subject string `db:"subject"`
+}
+
+// settings points at a local Postgres instance instead of demo.upper.io:
+// this example inserts new authors, subjects and books, and readers
+// shouldn't be writing to the shared hosted demo database. See the
+// bootstrap example for how to create and seed that local database.
+var settings = postgresql.ConnectionURL{
+	Database: `booktown`,
+	Address:  db.ParseAddress(`localhost`),
+	User:     `postgres`,
+	Password: ``,
+}
+
+func main() {
+	sess, err := db.Open("postgresql", settings)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer sess.Close()
+
+	builder := sess.(sqlbuilder.Database)
+
+	// Insert a new author, a new subject and a book that references both,
+	// all within the same transaction. If anything fails the whole batch
+	// is rolled back.
+	err = builder.Tx(func(tx sqlbuilder.Tx) error {
+		author := Author{Name: "William Gibson"}
+		authorsCol := tx.Collection("authors")
+		if _, err := authorsCol.Insert(&author); err != nil {
+			return err
+		}
+
+		subject := Subject{Name: "Cyberpunk"}
+		subjectsCol := tx.Collection("subjects")
+		if _, err := subjectsCol.Insert(&subject); err != nil {
+			return err
+		}
+
+		book := Book{
+			Title:     "Neuromancer",
+			AuthorID:  author.ID,
+			SubjectID: subject.ID,
+		}
+		booksCol := tx.Collection("books")
+		if _, err := booksCol.Insert(&book); err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Join books with their author and subject names.
+	iter := builder.Select("b.*", "a.name AS author", "s.name AS subject").
+		From("books b").
+		Join("authors a").On("a.id = b.author_id").
+		LeftJoin("subjects s").On("s.id = b.subject_id").
+		Iterator()
+
+	var books []BookDetails
+	if err := iter.All(&books); err != nil {
+		log.Fatal(err)
+	}
+
+	for _, book := range books {
+		log.Printf("%#v\n", book)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"log"
+
+	"upper.io/db.v2"            // Imports the main db package.
+	"upper.io/db.v2/postgresql" // Imports the postgresql adapter.
+
+	"upper.io/db.v2/webroot/examples/bootstrap/migrations"
+)
+
+// Book represents a book, this time with a jsonb Details column that can
+// hold arbitrary metadata such as rating, genres or a table of contents.
+type Book struct {
+	ID        int                 `db:"id,omitempty"`
+	Title     string              `db:"title"`
+	AuthorID  int                 `db:"author_id"`
+	SubjectID int                 `db:"subject_id"`
+	Details   postgresql.JSONBMap `db:"details"`
+}
+
+// settings points at a local Postgres instance instead of demo.upper.io:
+// this example inserts and updates rows, and the hosted demo database
+// doesn't have the details column migrations.Run adds below.
+var settings = postgresql.ConnectionURL{
+	Database: `booktown`,
+	Address:  db.ParseAddress(`localhost`),
+	User:     `postgres`,
+	Password: ``,
+}
+
+func main() {
+	sess, err := db.Open("postgresql", settings)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer sess.Close()
+
+	if err := migrations.Run(sess); err != nil {
+		log.Fatal(err)
+	}
+
+	col, err := sess.Collection("books")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Insert a book whose Details is a nested jsonb document.
+	newBook := Book{
+		Title:     "The Hunt for Red October",
+		AuthorID:  1,
+		SubjectID: 1,
+		Details: postgresql.JSONBMap{
+			"rating": 4.5,
+			"genres": []string{"thriller", "military fiction"},
+			"contents": map[string]interface{}{
+				"chapters": 32,
+				"pages":    387,
+			},
+		},
+	}
+
+	if _, err := col.Insert(&newBook); err != nil {
+		log.Fatal(err)
+	}
+
+	// Query by a jsonb path predicate using a raw expression: the
+	// postgresql adapter passes db.Raw() straight through to the driver.
+	res := col.Find(db.Raw("details->>'genres' LIKE ?", "%thriller%"))
+
+	var books []Book
+	if err := res.All(&books); err != nil {
+		log.Fatal(err)
+	}
+
+	for _, book := range books {
+		log.Printf("%#v\n", book)
+	}
+
+	// Partially update the Details column without clobbering the rest of
+	// the jsonb document, using Postgres' jsonb_set().
+	err = col.Find(db.Cond{"id": newBook.ID}).Update(struct {
+		Details db.RawValue `db:"details"`
+	}{
+		Details: db.Raw(`jsonb_set(details, '{rating}', '5.0')`),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
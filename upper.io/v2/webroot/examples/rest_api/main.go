@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"upper.io/db.v2"            // Imports the main db package.
+	"upper.io/db.v2/postgresql" // Imports the postgresql adapter.
+)
+
+// Book represents a book.
+type Book struct {
+	ID        int    `db:"id"`
+	Title     string `db:"title"`
+	AuthorID  int    `db:"author_id"`
+	SubjectID int    `db:"subject_id"`
+}
+
+var settings = postgresql.ConnectionURL{
+	Database: `booktown`, // Database name.
+	Address:  db.ParseAddress(`demo.upper.io`),
+	User:     `demouser`, // Database username.
+	Password: `demop4ss`, // Database password.
+}
+
+// booksAPI wires a db.Collection into a handful of HTTP handlers.
+type booksAPI struct {
+	col db.Collection
+}
+
+// list handles GET /api/books?sort=&limit=&offset=
+func (a *booksAPI) list(w http.ResponseWriter, r *http.Request) {
+	req := a.col.Find()
+
+	q := r.URL.Query()
+
+	if sort := q.Get("sort"); sort != "" {
+		req = req.Sort(sort) // Tip: Try "-id" for descending order.
+	}
+
+	limit, offset := 0, 0
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid offset", http.StatusBadRequest)
+			return
+		}
+		offset = n
+	}
+	if limit > 0 {
+		req = req.Paginate(uint(limit))
+	}
+	if offset > 0 {
+		req = req.Offset(uint(offset))
+	}
+
+	var books []Book
+	if err := req.All(&books); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(books); err != nil {
+		log.Printf("json.Encode: %v", err)
+	}
+}
+
+// get handles GET /api/books/{id}
+func (a *booksAPI) get(w http.ResponseWriter, r *http.Request) {
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/books/")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid book id", http.StatusNotFound)
+		return
+	}
+
+	var book Book
+	if err := a.col.Find(db.Cond{"id": id}).One(&book); err != nil {
+		if err == db.ErrNoMoreRows {
+			http.Error(w, "book not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(book); err != nil {
+		log.Printf("json.Encode: %v", err)
+	}
+}
+
+func (a *booksAPI) books(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/api/books" {
+		a.list(w, r)
+		return
+	}
+	a.get(w, r)
+}
+
+func main() {
+	sess, err := db.Open("postgresql", settings)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer sess.Close()
+
+	col, err := sess.Collection("books")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	api := &booksAPI{col: col}
+
+	http.HandleFunc("/api/books", api.books)
+	http.HandleFunc("/api/books/", api.books)
+
+	log.Println("listening on :8080")
+	log.Fatal(http.ListenAndServe(":8080", nil))
+}
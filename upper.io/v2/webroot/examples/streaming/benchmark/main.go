@@ -0,0 +1,90 @@
+package main
+
+import (
+	"log"
+	"runtime"
+	"time"
+
+	"upper.io/db.v2"            // Imports the main db package.
+	"upper.io/db.v2/postgresql" // Imports the postgresql adapter.
+)
+
+// Book represents a book.
+type Book struct {
+	ID        int    `db:"id"`
+	Title     string `db:"title"`
+	AuthorID  int    `db:"author_id"`
+	SubjectID int    `db:"subject_id"`
+}
+
+var settings = postgresql.ConnectionURL{
+	Database: `booktown`, // Database name.
+	Address:  db.ParseAddress(`demo.upper.io`),
+	User:     `demouser`, // Database username.
+	Password: `demop4ss`, // Database password.
+}
+
+// benchmark compares memory use and wall time between loading a result
+// set with .All and walking it with an iterator, against a large seed
+// dataset. Run this against a booktown database that has been seeded
+// with a few million rows.
+func main() {
+	sess, err := db.Open("postgresql", settings)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer sess.Close()
+
+	col, err := sess.Collection("books")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	runAll(col)
+	runIterator(col)
+}
+
+func runAll(col db.Collection) {
+	start := time.Now()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	var books []Book
+	if err := col.Find().Sort("id").All(&books); err != nil {
+		log.Fatal(err)
+	}
+
+	runtime.ReadMemStats(&after)
+
+	// TotalAlloc is a monotonic counter of bytes ever allocated, unlike
+	// Alloc (live heap), which can shrink across a GC and underflow the
+	// uint64 subtraction below.
+	log.Printf(".All: rows=%d elapsed=%s alloc=%d bytes\n",
+		len(books), time.Since(start), after.TotalAlloc-before.TotalAlloc)
+}
+
+func runIterator(col db.Collection) {
+	start := time.Now()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	iter := col.Find().Sort("id").Iterator()
+
+	var rows int
+	var book Book
+	for iter.Next(&book) {
+		rows++
+	}
+	if err := iter.Err(); err != nil {
+		log.Fatal(err)
+	}
+	iter.Close()
+
+	runtime.ReadMemStats(&after)
+
+	log.Printf(".Iterator: rows=%d elapsed=%s alloc=%d bytes\n",
+		rows, time.Since(start), after.TotalAlloc-before.TotalAlloc)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"log"
+
+	"upper.io/db.v2"            // Imports the main db package.
+	"upper.io/db.v2/postgresql" // Imports the postgresql adapter.
+)
+
+// Book represents a book.
+type Book struct {
+	ID        int    `db:"id"`
+	Title     string `db:"title"`
+	AuthorID  int    `db:"author_id"`
+	SubjectID int    `db:"subject_id"`
+}
+
+var settings = postgresql.ConnectionURL{
+	Database: `booktown`, // Database name.
+	Address:  db.ParseAddress(`demo.upper.io`),
+	User:     `demouser`, // Database username.
+	Password: `demop4ss`, // Database password.
+}
+
+func main() {
+	sess, err := db.Open("postgresql", settings)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer sess.Close()
+
+	col, err := sess.Collection("books")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// req.All(&books) loads the whole result set into memory, which is
+	// fine for a few thousand rows but not for millions. Instead, walk
+	// the rows with an iterator and a keyset (id > lastID) page, so
+	// memory use stays constant no matter how large the table is.
+	var lastID int
+
+	for {
+		req := col.Find(db.Cond{"id >": lastID}).Sort("id").Paginate(1000)
+
+		iter := req.Iterator()
+
+		var rowsInPage int
+		var book Book
+		for iter.Next(&book) {
+			rowsInPage++
+			lastID = book.ID
+			// ... process book here without ever holding the full
+			// result set in memory.
+		}
+
+		if err := iter.Err(); err != nil {
+			log.Fatal(err)
+		}
+		iter.Close()
+
+		if rowsInPage == 0 {
+			break
+		}
+	}
+
+	log.Printf("done, last id seen: %d\n", lastID)
+}